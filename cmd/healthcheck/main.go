@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
+	"go-health-checker/internal/alert"
+	"go-health-checker/internal/checker"
 	"go-health-checker/internal/config"
+	"go-health-checker/internal/httpserver"
+	"go-health-checker/internal/runner"
 )
 
 var (
@@ -15,20 +24,36 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		runSchema()
+		return
+	}
+
 	// Flags
-	configFile := flag.String("c", "", "Config file path (YAML/JSON)")
+	configFile := flag.String("c", "", "Config file path, directory (loads every config in it), or YAML/JSON")
 	timeout := flag.Duration("t", 5*time.Second, "Request timeout")
 	retries := flag.Int("r", 0, "Retry count on failure")
 	interval := flag.Duration("i", 30*time.Second, "Check interval (watch mode)")
+	failureThreshold := flag.Int("failure-threshold", 3, "Consecutive failures before an alert fires")
+	watch := flag.Bool("watch", false, "Keep checking every --i interval instead of exiting after one round")
+	once := flag.Bool("once", false, "Run a single check round and exit, even with --watch")
+	format := flag.String("format", "text", "Output format: text, json, ndjson, or prometheus")
+	metricsFile := flag.String("metrics-file", "", "Metrics textfile path (required for --format prometheus)")
+	serveAddr := flag.String("serve", "", "Address to serve /metrics, /health/all, and /health/live on (e.g. :8080)")
+	retryTimeout := flag.Duration("retry-timeout", 0, "Keep re-running the whole check batch every --sleep until all endpoints pass or this deadline elapses (CI/CD gating mode)")
+	sleep := flag.Duration("sleep", 5*time.Second, "Sleep between --retry-timeout attempts")
 	showVersion := flag.Bool("version", false, "Show version")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: healthcheck [flags] [urls...]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: healthcheck [flags] [urls...]\n")
+		fmt.Fprintf(os.Stderr, "       healthcheck schema\n\n")
 		fmt.Fprintf(os.Stderr, "A fast, concurrent API health checker.\n\n")
 		fmt.Fprintf(os.Stderr, "Examples:\n")
 		fmt.Fprintf(os.Stderr, "  healthcheck https://api.example.com/health\n")
 		fmt.Fprintf(os.Stderr, "  healthcheck -c endpoints.yml --watch\n")
-		fmt.Fprintf(os.Stderr, "  healthcheck -c endpoints.yml --format json\n\n")
+		fmt.Fprintf(os.Stderr, "  healthcheck -c endpoints.yml --format json\n")
+		fmt.Fprintf(os.Stderr, "  healthcheck -c conf.d/ --once\n")
+		fmt.Fprintf(os.Stderr, "  healthcheck schema > healthcheck.schema.json\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		flag.PrintDefaults()
 	}
@@ -42,14 +67,16 @@ func main() {
 
 	// Build endpoint list from config file and/or CLI args
 	var endpoints []config.Endpoint
+	var alerts config.Alerts
 
 	if *configFile != "" {
-		cfg, err := config.LoadFile(*configFile)
+		cfg, err := loadConfigPath(*configFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 			os.Exit(1)
 		}
 		endpoints = cfg.Endpoints
+		alerts = cfg.Alerts
 
 		// Apply settings from config if not overridden by flags
 		if cfg.Settings.Timeout > 0 {
@@ -61,6 +88,12 @@ func main() {
 		if cfg.Settings.Interval > 0 {
 			*interval = cfg.Settings.Interval
 		}
+		if cfg.Settings.FailureThreshold > 0 {
+			*failureThreshold = cfg.Settings.FailureThreshold
+		}
+		if cfg.Settings.RetryTimeout > 0 {
+			*retryTimeout = cfg.Settings.RetryTimeout
+		}
 	}
 
 	// Add URLs from CLI arguments
@@ -82,4 +115,76 @@ func main() {
 		os.Exit(1)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	engine := checker.NewEngine(checker.Options{Timeout: *timeout, Retries: *retries})
+
+	if *retryTimeout > 0 {
+		passed, err := runner.RetryUntilPass(ctx, engine, endpoints, *retryTimeout, *sleep, os.Stdout)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !passed {
+			fmt.Fprintln(os.Stderr, "Error: endpoints did not become healthy before --retry-timeout elapsed")
+			os.Exit(3)
+		}
+		os.Exit(0)
+	}
+
+	reporter, err := runner.NewReporter(*format, os.Stdout, *metricsFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *serveAddr != "" {
+		srv := httpserver.NewServer()
+		go func() {
+			if err := http.ListenAndServe(*serveAddr, srv.Handler()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: http server: %v\n", err)
+			}
+		}()
+		reporter = runner.MultiReporter{Reporters: []runner.Reporter{reporter, srv}}
+	}
+
+	var dispatcher *alert.Dispatcher
+	if alerts.Slack != nil || alerts.Webhook != nil {
+		dispatcher = alert.NewDispatcher(alerts, *failureThreshold)
+	}
+
+	healthy, err := runner.Watch(ctx, engine, endpoints, *interval, reporter, dispatcher, *once || !*watch)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !healthy {
+		os.Exit(1)
+	}
+}
+
+// loadConfigPath loads path as a single config file, or as a directory of
+// merged config files if it is one.
+func loadConfigPath(path string) (*config.Config, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return config.LoadDir(path)
+	}
+	return config.LoadFile(path)
+}
+
+// runSchema implements the "healthcheck schema" subcommand: it prints the
+// JSON Schema document for the config file format to stdout.
+func runSchema() {
+	schema, err := config.JSONSchema()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(schema))
 }
@@ -0,0 +1,152 @@
+// Package alert tracks per-endpoint health state across check rounds and
+// notifies configured backends when an endpoint transitions between
+// healthy and failing.
+package alert
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go-health-checker/internal/checker"
+	"go-health-checker/internal/config"
+)
+
+// dedupeWindow is the minimum time between repeated notifications of the
+// same transition type for an endpoint, to avoid alert storms while an
+// endpoint flaps. It is a var, not a const, so tests can shrink it.
+var dedupeWindow = 10 * time.Minute
+
+// status is an endpoint's alerting state, distinct from a single check's
+// Healthy bool: it only flips after FailureThreshold consecutive failures.
+type status int
+
+const (
+	statusHealthy status = iota
+	statusFailing
+)
+
+// endpointState tracks one endpoint's alerting history across rounds.
+// lastFailureNotified and lastRecoveryNotified are deduped independently
+// so a recovery right after a deliberately-notified failure still fires.
+type endpointState struct {
+	status               status
+	consecutiveFailures  int
+	firstFailureAt       time.Time // set on the first consecutive failure, before the threshold gates notification
+	failingSince         time.Time
+	lastFailureNotified  time.Time
+	lastRecoveryNotified time.Time
+}
+
+// Dispatcher consumes check results each round, maintains per-endpoint
+// alerting state, and fires notifications only on state transitions.
+type Dispatcher struct {
+	threshold int
+	notifiers []Notifier
+
+	states map[string]*endpointState
+}
+
+// NewDispatcher builds a Dispatcher from the configured alert backends.
+// threshold is the number of consecutive failures required before an
+// OnFailure notification fires; it must be at least 1.
+func NewDispatcher(cfg config.Alerts, threshold int) *Dispatcher {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	var notifiers []Notifier
+	if cfg.Slack != nil {
+		notifiers = append(notifiers, NewSlackNotifier(*cfg.Slack))
+	}
+	if cfg.Webhook != nil {
+		notifiers = append(notifiers, NewWebhookNotifier(*cfg.Webhook))
+	}
+
+	return &Dispatcher{
+		threshold: threshold,
+		notifiers: notifiers,
+		states:    make(map[string]*endpointState),
+	}
+}
+
+// Dispatch processes one round of results, updating per-endpoint state and
+// notifying on OnFailure/OnRecovery transitions.
+func (d *Dispatcher) Dispatch(ctx context.Context, results []checker.Result) {
+	for _, r := range results {
+		d.dispatchOne(ctx, r)
+	}
+}
+
+func (d *Dispatcher) dispatchOne(ctx context.Context, r checker.Result) {
+	state, ok := d.states[r.Name]
+	if !ok {
+		state = &endpointState{status: statusHealthy}
+		d.states[r.Name] = state
+	}
+
+	if r.Healthy {
+		wasFailing := state.status == statusFailing
+		state.consecutiveFailures = 0
+
+		if wasFailing {
+			state.status = statusHealthy
+			downtime := time.Since(state.failingSince)
+
+			if time.Since(state.lastRecoveryNotified) < dedupeWindow {
+				return
+			}
+			state.lastRecoveryNotified = time.Now()
+
+			d.notify(ctx, Event{
+				Result:        r,
+				PreviousState: "failing",
+				DowntimeSince: state.failingSince,
+				DowntimeFor:   downtime,
+				IsRecovery:    true,
+			})
+		}
+		return
+	}
+
+	if state.consecutiveFailures == 0 {
+		state.firstFailureAt = time.Now()
+	}
+	state.consecutiveFailures++
+
+	if state.status == statusFailing {
+		return // already alerted; wait for recovery
+	}
+
+	if state.consecutiveFailures < d.threshold {
+		return // not yet past the failure threshold
+	}
+
+	state.status = statusFailing
+	state.failingSince = state.firstFailureAt
+
+	if time.Since(state.lastFailureNotified) < dedupeWindow {
+		return
+	}
+	state.lastFailureNotified = time.Now()
+
+	d.notify(ctx, Event{
+		Result:        r,
+		PreviousState: "healthy",
+		IsRecovery:    false,
+	})
+}
+
+func (d *Dispatcher) notify(ctx context.Context, event Event) {
+	for _, n := range d.notifiers {
+		var err error
+		if event.IsRecovery {
+			err = notifyWithRetry(ctx, func(ctx context.Context) error { return n.NotifyRecovery(ctx, event) })
+		} else {
+			err = notifyWithRetry(ctx, func(ctx context.Context) error { return n.NotifyFailure(ctx, event) })
+		}
+		if err != nil {
+			log.Printf("alert: notify %s failed for %s: %v", n.Name(), event.Result.Name, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-health-checker/internal/checker"
+)
+
+// fakeNotifier records how many failure/recovery notifications it received,
+// and the last recovery event, without making any network calls.
+type fakeNotifier struct {
+	failures   int
+	recoveries int
+
+	lastRecovery Event
+}
+
+func (f *fakeNotifier) Name() string { return "fake" }
+
+func (f *fakeNotifier) NotifyFailure(ctx context.Context, event Event) error {
+	f.failures++
+	return nil
+}
+
+func (f *fakeNotifier) NotifyRecovery(ctx context.Context, event Event) error {
+	f.recoveries++
+	f.lastRecovery = event
+	return nil
+}
+
+func newTestDispatcher(threshold int, n *fakeNotifier) *Dispatcher {
+	return &Dispatcher{
+		threshold: threshold,
+		notifiers: []Notifier{n},
+		states:    make(map[string]*endpointState),
+	}
+}
+
+func result(name string, healthy bool) checker.Result {
+	return checker.Result{Name: name, Healthy: healthy}
+}
+
+func TestDispatchOneWaitsForFailureThreshold(t *testing.T) {
+	n := &fakeNotifier{}
+	d := newTestDispatcher(3, n)
+	ctx := context.Background()
+
+	d.dispatchOne(ctx, result("svc", false))
+	d.dispatchOne(ctx, result("svc", false))
+	if n.failures != 0 {
+		t.Fatalf("expected no notification before threshold, got %d", n.failures)
+	}
+
+	d.dispatchOne(ctx, result("svc", false))
+	if n.failures != 1 {
+		t.Fatalf("expected 1 notification at threshold, got %d", n.failures)
+	}
+
+	d.dispatchOne(ctx, result("svc", false))
+	if n.failures != 1 {
+		t.Fatalf("expected no repeat notification while still failing, got %d", n.failures)
+	}
+}
+
+func TestDispatchOneNotifiesOnRecovery(t *testing.T) {
+	n := &fakeNotifier{}
+	d := newTestDispatcher(1, n)
+	ctx := context.Background()
+
+	d.dispatchOne(ctx, result("svc", false))
+	if n.failures != 1 {
+		t.Fatalf("expected 1 failure notification, got %d", n.failures)
+	}
+
+	d.dispatchOne(ctx, result("svc", true))
+	if n.recoveries != 1 {
+		t.Fatalf("expected 1 recovery notification, got %d", n.recoveries)
+	}
+
+	// A further healthy result is not itself a transition.
+	d.dispatchOne(ctx, result("svc", true))
+	if n.recoveries != 1 {
+		t.Fatalf("expected no repeat recovery notification while already healthy, got %d", n.recoveries)
+	}
+}
+
+// TestDispatchOneDowntimeIncludesSubThresholdFailures checks that the
+// reported downtime covers the outage from its first observed failure, not
+// just from the point FailureThreshold was crossed and a notification
+// actually fired.
+func TestDispatchOneDowntimeIncludesSubThresholdFailures(t *testing.T) {
+	n := &fakeNotifier{}
+	d := newTestDispatcher(3, n)
+	ctx := context.Background()
+
+	d.dispatchOne(ctx, result("svc", false))
+	firstFailureAt := time.Now()
+
+	time.Sleep(20 * time.Millisecond)
+	d.dispatchOne(ctx, result("svc", false)) // 2nd failure, still below threshold
+	d.dispatchOne(ctx, result("svc", false)) // 3rd failure, crosses threshold and notifies
+
+	time.Sleep(20 * time.Millisecond)
+	d.dispatchOne(ctx, result("svc", true)) // recovers
+
+	if n.recoveries != 1 {
+		t.Fatalf("expected 1 recovery notification, got %d", n.recoveries)
+	}
+	if n.lastRecovery.DowntimeSince.After(firstFailureAt.Add(5 * time.Millisecond)) {
+		t.Fatalf("expected DowntimeSince near the first failure (%v), got %v", firstFailureAt, n.lastRecovery.DowntimeSince)
+	}
+	if n.lastRecovery.DowntimeFor < 40*time.Millisecond {
+		t.Fatalf("expected DowntimeFor to cover both sleeps (>=40ms), got %v", n.lastRecovery.DowntimeFor)
+	}
+}
+
+// TestDispatchOneDedupesFlapping guards against both directions of the
+// flapping alert storm: repeated failure notifications and repeated
+// recovery notifications within the dedupe window.
+func TestDispatchOneDedupesFlapping(t *testing.T) {
+	orig := dedupeWindow
+	dedupeWindow = time.Hour
+	defer func() { dedupeWindow = orig }()
+
+	n := &fakeNotifier{}
+	d := newTestDispatcher(1, n)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		d.dispatchOne(ctx, result("svc", false))
+		d.dispatchOne(ctx, result("svc", true))
+	}
+
+	if n.failures != 1 {
+		t.Fatalf("expected failure notifications deduped to 1, got %d", n.failures)
+	}
+	if n.recoveries != 1 {
+		t.Fatalf("expected recovery notifications deduped to 1, got %d", n.recoveries)
+	}
+}
@@ -0,0 +1,198 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-health-checker/internal/checker"
+	"go-health-checker/internal/config"
+)
+
+// Event describes a single alerting state transition for one endpoint.
+type Event struct {
+	Result        checker.Result
+	PreviousState string // "healthy" or "failing"
+	IsRecovery    bool
+	DowntimeSince time.Time     // zero unless IsRecovery
+	DowntimeFor   time.Duration // zero unless IsRecovery
+}
+
+// Notifier delivers failure and recovery events to a backend.
+type Notifier interface {
+	Name() string
+	NotifyFailure(ctx context.Context, event Event) error
+	NotifyRecovery(ctx context.Context, event Event) error
+}
+
+// notifyWithRetry retries a notifier delivery with exponential backoff,
+// giving up after four attempts.
+func notifyWithRetry(ctx context.Context, deliver func(ctx context.Context) error) error {
+	const maxAttempts = 4
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		if lastErr = deliver(ctx); lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", maxAttempts, lastErr)
+}
+
+// SlackNotifier posts a legacy color-coded attachment to a Slack incoming
+// webhook. It intentionally uses the attachments API rather than Block
+// Kit: Block Kit has no equivalent of an attachment's color bar, which is
+// how failure/recovery is visually distinguished here.
+type SlackNotifier struct {
+	cfg    config.SlackAlert
+	client *http.Client
+}
+
+// NewSlackNotifier builds a SlackNotifier from Slack alert config.
+func NewSlackNotifier(cfg config.SlackAlert) *SlackNotifier {
+	return &SlackNotifier{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *SlackNotifier) Name() string { return "slack" }
+
+func (s *SlackNotifier) NotifyFailure(ctx context.Context, event Event) error {
+	if !s.cfg.OnFailure {
+		return nil
+	}
+	return s.post(ctx, "danger", fmt.Sprintf("🔴 %s is DOWN", event.Result.Name), event)
+}
+
+func (s *SlackNotifier) NotifyRecovery(ctx context.Context, event Event) error {
+	if !s.cfg.OnRecovery {
+		return nil
+	}
+	title := fmt.Sprintf("✅ %s has RECOVERED (down for %s)", event.Result.Name, event.DowntimeFor.Round(time.Second))
+	return s.post(ctx, "good", title, event)
+}
+
+func (s *SlackNotifier) post(ctx context.Context, color, title string, event Event) error {
+	payload := slackPayload{
+		Attachments: []slackAttachment{{
+			Color: color,
+			Title: title,
+			Fields: []slackField{
+				{Title: "Endpoint", Value: event.Result.Name, Short: true},
+				{Title: "URL", Value: event.Result.URL, Short: true},
+				{Title: "Latency", Value: event.Result.Latency.String(), Short: true},
+				{Title: "Error", Value: event.Result.Error, Short: false},
+			},
+		}},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type slackPayload struct {
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Title  string       `json:"title"`
+	Fields []slackField `json:"fields"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// WebhookNotifier POSTs the raw Result plus the previous state as JSON to
+// a generic webhook URL.
+type WebhookNotifier struct {
+	cfg    config.WebhookAlert
+	client *http.Client
+}
+
+// NewWebhookNotifier builds a WebhookNotifier from webhook alert config.
+func NewWebhookNotifier(cfg config.WebhookAlert) *WebhookNotifier {
+	return &WebhookNotifier{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *WebhookNotifier) Name() string { return "webhook" }
+
+func (w *WebhookNotifier) NotifyFailure(ctx context.Context, event Event) error {
+	if !w.cfg.OnFailure {
+		return nil
+	}
+	return w.post(ctx, event)
+}
+
+func (w *WebhookNotifier) NotifyRecovery(ctx context.Context, event Event) error {
+	if !w.cfg.OnRecovery {
+		return nil
+	}
+	return w.post(ctx, event)
+}
+
+func (w *WebhookNotifier) post(ctx context.Context, event Event) error {
+	payload := webhookPayload{
+		Result:        event.Result,
+		PreviousState: event.PreviousState,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	checker.Result
+	PreviousState string `json:"previous_state"`
+}
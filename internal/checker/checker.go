@@ -12,6 +12,12 @@ import (
 	"time"
 
 	"go-health-checker/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // Result holds the outcome of a health check.
@@ -40,6 +46,10 @@ type Options struct {
 type Engine struct {
 	opts   Options
 	client *http.Client
+
+	// grpcConns caches one *grpc.ClientConn per host and TLS setting so repeated watch-mode
+	// checks reuse connections instead of dialing on every tick.
+	grpcConns sync.Map
 }
 
 // NewEngine creates a new health check engine.
@@ -218,7 +228,8 @@ func (e *Engine) checkTCP(ctx context.Context, ep config.Endpoint) Result {
 	return result
 }
 
-// checkGRPC performs a gRPC health check.
+// checkGRPC performs a gRPC health check using the standard
+// grpc.health.v1.Health/Check RPC.
 func (e *Engine) checkGRPC(ctx context.Context, ep config.Endpoint) Result {
 	result := Result{
 		Name:      ep.Name,
@@ -227,20 +238,72 @@ func (e *Engine) checkGRPC(ctx context.Context, ep config.Endpoint) Result {
 		Timestamp: time.Now().UTC(),
 	}
 
-	// For now, do a TCP check to the gRPC port
-	// In a full implementation, use grpc-health-probe protocol
-	dialer := &net.Dialer{Timeout: e.opts.Timeout}
+	timeout := e.opts.Timeout
+	if ep.Timeout > 0 {
+		timeout = ep.Timeout
+	}
+
+	conn, err := e.grpcConn(ep)
+	if err != nil {
+		result.Error = fmt.Sprintf("grpc dial: %v", err)
+		return result
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client := healthpb.NewHealthClient(conn)
 
 	start := time.Now()
-	conn, err := dialer.DialContext(ctx, "tcp", ep.Host)
+	resp, err := client.Check(checkCtx, &healthpb.HealthCheckRequest{Service: ep.Service})
 	result.Latency = time.Since(start)
 
 	if err != nil {
-		result.Error = fmt.Sprintf("grpc connect: %v", err)
+		st, _ := status.FromError(err)
+		result.Error = fmt.Sprintf("grpc check failed: code=%s message=%s", st.Code(), st.Message())
+		return result
+	}
+
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		result.Error = fmt.Sprintf("grpc status: %s", resp.Status)
 		return result
 	}
-	conn.Close()
 
 	result.Healthy = true
 	return result
 }
+
+// grpcConnKey identifies a cached connection by both host and TLS setting,
+// so two endpoints sharing a host:port but dialed with different transport
+// security never share a connection.
+type grpcConnKey struct {
+	host string
+	tls  bool
+}
+
+// grpcConn returns a cached *grpc.ClientConn for (ep.Host, ep.TLS), dialing
+// and caching a new one on first use so repeated watch-mode checks don't
+// churn connections.
+func (e *Engine) grpcConn(ep config.Endpoint) (*grpc.ClientConn, error) {
+	key := grpcConnKey{host: ep.Host, tls: ep.TLS}
+
+	if v, ok := e.grpcConns.Load(key); ok {
+		return v.(*grpc.ClientConn), nil
+	}
+
+	creds := insecure.NewCredentials()
+	if ep.TLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+
+	conn, err := grpc.NewClient(ep.Host, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	actual, loaded := e.grpcConns.LoadOrStore(key, conn)
+	if loaded {
+		conn.Close()
+	}
+	return actual.(*grpc.ClientConn), nil
+}
@@ -3,8 +3,10 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,13 +18,20 @@ type Config struct {
 	Settings  Settings   `yaml:"settings" json:"settings"`
 	Endpoints []Endpoint `yaml:"endpoints" json:"endpoints"`
 	Alerts    Alerts     `yaml:"alerts" json:"alerts"`
+
+	// endpointLines maps an endpoint's index to its source line, populated
+	// by LoadFile for YAML sources so Validate can report file:line
+	// context. It is unexported and so ignored by both encoders.
+	endpointLines map[int]int
 }
 
 // Settings holds global check settings.
 type Settings struct {
-	Timeout  time.Duration `yaml:"timeout" json:"timeout"`
-	Retries  int           `yaml:"retries" json:"retries"`
-	Interval time.Duration `yaml:"interval" json:"interval"`
+	Timeout          time.Duration `yaml:"timeout" json:"timeout"`
+	Retries          int           `yaml:"retries" json:"retries"`
+	Interval         time.Duration `yaml:"interval" json:"interval"`
+	FailureThreshold int           `yaml:"failure_threshold" json:"failure_threshold"` // Consecutive failures before an alert fires
+	RetryTimeout     time.Duration `yaml:"retry_timeout" json:"retry_timeout"`         // Global deadline for retry-until-pass mode
 }
 
 // Endpoint defines a single health check target.
@@ -38,6 +47,8 @@ type Endpoint struct {
 	Headers              map[string]string `yaml:"headers" json:"headers"`
 	Body                 string            `yaml:"body" json:"body"`
 	Timeout              time.Duration     `yaml:"timeout" json:"timeout"` // Per-endpoint override
+	Service              string            `yaml:"service" json:"service"` // For gRPC: registered service name, empty for overall server health
+	TLS                  bool              `yaml:"tls" json:"tls"`         // For gRPC: dial with TLS transport credentials
 }
 
 // Alerts defines notification configuration.
@@ -60,7 +71,7 @@ type WebhookAlert struct {
 	OnRecovery bool   `yaml:"on_recovery" json:"on_recovery"`
 }
 
-// LoadFile reads and parses a config file.
+// LoadFile reads, parses, and validates a single config file.
 func LoadFile(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -75,11 +86,16 @@ func LoadFile(path string) (*Config, error) {
 	ext := strings.ToLower(filepath.Ext(path))
 	switch ext {
 	case ".yml", ".yaml":
-		if err := yaml.Unmarshal([]byte(expanded), cfg); err != nil {
+		dec := yaml.NewDecoder(strings.NewReader(expanded))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && err != io.EOF {
 			return nil, fmt.Errorf("parse YAML config: %w", err)
 		}
+		cfg.endpointLines = yamlEndpointLines([]byte(expanded))
 	case ".json":
-		if err := json.Unmarshal([]byte(expanded), cfg); err != nil {
+		dec := json.NewDecoder(strings.NewReader(expanded))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
 			return nil, fmt.Errorf("parse JSON config: %w", err)
 		}
 	default:
@@ -106,5 +122,78 @@ func LoadFile(path string) (*Config, error) {
 		}
 	}
 
+	if err := cfg.Validate(path); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
+
+// yamlEndpointLines walks the raw YAML document to record the source line
+// of each entry under "endpoints", without needing a custom Unmarshaler on
+// Endpoint (which would fight KnownFields strictness).
+func yamlEndpointLines(data []byte) map[int]int {
+	lines := make(map[int]int)
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return lines
+	}
+
+	doc := root.Content[0]
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "endpoints" {
+			continue
+		}
+		for idx, item := range doc.Content[i+1].Content {
+			lines[idx] = item.Line
+		}
+	}
+
+	return lines
+}
+
+// LoadDir merges every *.yml/*.yaml/*.json file in dir into a single
+// Config: endpoints are appended across files in name order, while
+// Settings and Alerts come from a dedicated "_settings.yml"
+// (or "_settings.yaml"/"_settings.json") file, if present.
+func LoadDir(dir string) (*Config, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read config dir: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yml", ".yaml", ".json":
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	merged := &Config{}
+	for _, name := range names {
+		cfg, err := LoadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		if isSettingsFile(name) {
+			merged.Settings = cfg.Settings
+			merged.Alerts = cfg.Alerts
+			continue
+		}
+
+		merged.Endpoints = append(merged.Endpoints, cfg.Endpoints...)
+	}
+
+	return merged, nil
+}
+
+func isSettingsFile(name string) bool {
+	return strings.TrimSuffix(name, filepath.Ext(name)) == "_settings"
+}
@@ -0,0 +1,78 @@
+package config
+
+import "encoding/json"
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document describing the
+// config file format, so editors and CI can validate configs before they
+// ever reach LoadFile.
+func JSONSchema() ([]byte, error) {
+	durationString := map[string]interface{}{
+		"type":        []string{"string", "integer"},
+		"description": "Duration: a Go duration string such as \"5s\" or \"1m30s\" in YAML configs, integer nanoseconds in JSON configs",
+	}
+
+	settings := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"timeout":           durationString,
+			"retries":           map[string]interface{}{"type": "integer", "minimum": 0},
+			"interval":          durationString,
+			"failure_threshold": map[string]interface{}{"type": "integer", "minimum": 1},
+			"retry_timeout":     durationString,
+		},
+		"additionalProperties": false,
+	}
+
+	endpoint := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":                   map[string]interface{}{"type": "string"},
+			"url":                    map[string]interface{}{"type": "string"},
+			"type":                   map[string]interface{}{"type": "string", "enum": []string{"http", "tcp", "grpc"}},
+			"method":                 map[string]interface{}{"type": "string", "enum": []string{"GET", "POST", "HEAD", "PUT", "DELETE", "PATCH", "OPTIONS"}},
+			"host":                   map[string]interface{}{"type": "string"},
+			"port":                   map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 65535},
+			"expected_status":        map[string]interface{}{"type": "integer"},
+			"expected_body_contains": map[string]interface{}{"type": "string"},
+			"headers":                map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}},
+			"body":                   map[string]interface{}{"type": "string"},
+			"timeout":                durationString,
+			"service":                map[string]interface{}{"type": "string", "description": "gRPC: registered service name, empty for overall server health"},
+			"tls":                    map[string]interface{}{"type": "boolean"},
+		},
+		"additionalProperties": false,
+	}
+
+	alertBackend := func(urlField string) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				urlField:      map[string]interface{}{"type": "string"},
+				"on_failure":  map[string]interface{}{"type": "boolean"},
+				"on_recovery": map[string]interface{}{"type": "boolean"},
+			},
+			"additionalProperties": false,
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "go-health-checker config",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"settings":  settings,
+			"endpoints": map[string]interface{}{"type": "array", "items": endpoint},
+			"alerts": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"slack":   alertBackend("webhook_url"),
+					"webhook": alertBackend("url"),
+				},
+				"additionalProperties": false,
+			},
+		},
+		"additionalProperties": false,
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ValidationError describes one problem found by Config.Validate, with
+// file:line context where available (populated for YAML sources; other
+// sources report the file alone).
+type ValidationError struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Msg)
+}
+
+// ValidationErrors collects every problem Config.Validate finds in one
+// pass, rather than stopping at the first one.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, ve := range e {
+		lines[i] = ve.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+var validMethods = map[string]bool{
+	"GET": true, "POST": true, "HEAD": true, "PUT": true,
+	"DELETE": true, "PATCH": true, "OPTIONS": true,
+}
+
+// headerKeyRE matches a valid HTTP header field-name token (RFC 7230 3.2.6).
+var headerKeyRE = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+\-.^_` + "`" + `|~]+$`)
+
+// Validate checks every endpoint for the problems LoadFile's permissive
+// defaulting would otherwise paper over: malformed URLs, disallowed HTTP
+// methods, out-of-range ports, unknown endpoint types, and invalid header
+// keys. It returns a ValidationErrors listing every problem found, or nil.
+func (c *Config) Validate(file string) error {
+	var errs ValidationErrors
+
+	for i, ep := range c.Endpoints {
+		line := c.endpointLines[i]
+		addErr := func(format string, args ...interface{}) {
+			msg := fmt.Sprintf("endpoint %d (%s): %s", i, ep.Name, fmt.Sprintf(format, args...))
+			errs = append(errs, &ValidationError{File: file, Line: line, Msg: msg})
+		}
+
+		switch ep.Type {
+		case "http", "":
+			if ep.URL == "" {
+				addErr("http endpoint requires a url")
+				break
+			}
+			u, err := url.Parse(ep.URL)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				addErr("invalid url %q", ep.URL)
+			}
+			if ep.Method != "" && !validMethods[strings.ToUpper(ep.Method)] {
+				addErr("unsupported method %q", ep.Method)
+			}
+		case "tcp":
+			if ep.Host == "" {
+				addErr("tcp endpoint requires a host")
+			}
+			if ep.Port < 1 || ep.Port > 65535 {
+				addErr("port %d out of range (1-65535)", ep.Port)
+			}
+		case "grpc":
+			if ep.Host == "" {
+				addErr("grpc endpoint requires a host")
+			}
+		default:
+			addErr("unknown endpoint type %q (want http, tcp, or grpc)", ep.Type)
+		}
+
+		for k := range ep.Headers {
+			if !headerKeyRE.MatchString(k) {
+				addErr("invalid header key %q", k)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
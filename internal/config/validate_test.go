@@ -0,0 +1,58 @@
+package config
+
+import "testing"
+
+func TestValidateAcceptsWellFormedEndpoints(t *testing.T) {
+	cfg := &Config{
+		Endpoints: []Endpoint{
+			{Name: "api", Type: "http", URL: "https://api.example.com/health", Method: "GET"},
+			{Name: "db", Type: "tcp", Host: "db.internal", Port: 5432},
+			{Name: "svc", Type: "grpc", Host: "svc.internal:50051"},
+		},
+	}
+
+	if err := cfg.Validate("endpoints.yml"); err != nil {
+		t.Fatalf("expected no validation errors, got: %v", err)
+	}
+}
+
+func TestValidateRejectsMalformedEndpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		ep   Endpoint
+	}{
+		{"tcp with no port", Endpoint{Name: "db", Type: "tcp", Host: "db.internal"}},
+		{"tcp with out-of-range port", Endpoint{Name: "db", Type: "tcp", Host: "db.internal", Port: 99999}},
+		{"http with bad method", Endpoint{Name: "api", Type: "http", URL: "https://api.example.com", Method: "FETCH"}},
+		{"http with unparseable url", Endpoint{Name: "api", Type: "http", URL: "://bad-url"}},
+		{"http with missing host", Endpoint{Name: "api", Type: "http", URL: "justapath"}},
+		{"unknown type", Endpoint{Name: "mystery", Type: "carrier-pigeon"}},
+		{"invalid header key", Endpoint{Name: "api", Type: "http", URL: "https://api.example.com", Headers: map[string]string{"Bad Header": "v"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Endpoints: []Endpoint{tt.ep}}
+			if err := cfg.Validate("endpoints.yml"); err == nil {
+				t.Fatalf("expected a validation error for %+v, got none", tt.ep)
+			}
+		})
+	}
+}
+
+func TestValidateReportsFileLineContext(t *testing.T) {
+	cfg := &Config{
+		Endpoints:     []Endpoint{{Name: "db", Type: "tcp", Host: "db.internal"}},
+		endpointLines: map[int]int{0: 7},
+	}
+
+	err := cfg.Validate("endpoints.yml")
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+
+	want := "endpoints.yml:7:"
+	if got := err.Error(); len(got) < len(want) || got[:len(want)] != want {
+		t.Fatalf("expected error to start with %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,125 @@
+// Package httpserver exposes the checker's latest results over HTTP: a
+// Prometheus /metrics endpoint, an aggregated /health/all document, and a
+// /health/live liveness probe for the checker process itself.
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"go-health-checker/internal/checker"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server caches the latest check results and serves them as Prometheus
+// metrics and as aggregated/liveness JSON documents.
+type Server struct {
+	mu      sync.RWMutex
+	results map[string]checker.Result
+
+	registry      *prometheus.Registry
+	up            *prometheus.GaugeVec
+	latency       *prometheus.HistogramVec
+	statusCode    *prometheus.GaugeVec
+	lastCheckedAt *prometheus.GaugeVec
+}
+
+// NewServer builds a Server with its own Prometheus registry, holding
+// only the healthcheck_* metrics. Each round's Report sets the series of
+// the endpoints in that round; series of other endpoints are not removed.
+func NewServer() *Server {
+	s := &Server{
+		results:  make(map[string]checker.Result),
+		registry: prometheus.NewRegistry(),
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "healthcheck_up",
+			Help: "Whether the last check for this endpoint was healthy (1) or not (0).",
+		}, []string{"endpoint"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "healthcheck_latency_seconds",
+			Help:    "Latency of checks against this endpoint, in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		statusCode: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "healthcheck_status_code",
+			Help: "HTTP status code returned by the last check, if applicable.",
+		}, []string{"endpoint"}),
+		lastCheckedAt: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "healthcheck_last_check_timestamp",
+			Help: "Unix timestamp of the last check for this endpoint.",
+		}, []string{"endpoint"}),
+	}
+
+	s.registry.MustRegister(s.up, s.latency, s.statusCode, s.lastCheckedAt)
+	return s
+}
+
+// Report records a round of results, satisfying runner.Reporter so Server
+// can be composed with other reporters via runner.MultiReporter.
+func (s *Server) Report(results []checker.Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range results {
+		s.results[r.Name] = r
+		s.up.WithLabelValues(r.Name).Set(boolToFloat64(r.Healthy))
+		s.latency.WithLabelValues(r.Name).Observe(r.Latency.Seconds())
+		s.statusCode.WithLabelValues(r.Name).Set(float64(r.StatusCode))
+		s.lastCheckedAt.WithLabelValues(r.Name).Set(float64(r.Timestamp.Unix()))
+	}
+	return nil
+}
+
+// Handler returns the http.Handler exposing /metrics, /health/all, and
+// /health/live, ready to pass to http.ListenAndServe.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/health/all", s.handleHealthAll)
+	mux.HandleFunc("/health/live", s.handleHealthLive)
+	return mux
+}
+
+// healthAllResponse is the aggregated document served at /health/all.
+type healthAllResponse struct {
+	Healthy bool                      `json:"healthy"`
+	Results map[string]checker.Result `json:"results"`
+}
+
+// handleHealthAll returns 200 if every cached result is healthy, 503
+// otherwise, matching the Kubernetes readiness probe convention.
+func (s *Server) handleHealthAll(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resp := healthAllResponse{Healthy: true, Results: make(map[string]checker.Result, len(s.results))}
+	for name, result := range s.results {
+		resp.Results[name] = result
+		if !result.Healthy {
+			resp.Healthy = false
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleHealthLive always reports the checker process itself as live; it
+// does not depend on endpoint health.
+func (s *Server) handleHealthLive(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "live"})
+}
+
+func boolToFloat64(healthy bool) float64 {
+	if healthy {
+		return 1
+	}
+	return 0
+}
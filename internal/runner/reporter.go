@@ -0,0 +1,156 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"go-health-checker/internal/checker"
+)
+
+// Reporter streams a round of check results to a destination in a
+// specific format. Report is called once per check round.
+type Reporter interface {
+	Report(results []checker.Result) error
+}
+
+// NewReporter builds the Reporter for the given --format value. w is used
+// by the text, json, and ndjson reporters; metricsFile is required by the
+// prometheus reporter.
+func NewReporter(format string, w io.Writer, metricsFile string) (Reporter, error) {
+	switch format {
+	case "", "text":
+		return &textReporter{w: w}, nil
+	case "json":
+		return &jsonReporter{w: w}, nil
+	case "ndjson":
+		return &ndjsonReporter{w: w}, nil
+	case "prometheus":
+		if metricsFile == "" {
+			return nil, fmt.Errorf("--format prometheus requires --metrics-file")
+		}
+		return &prometheusReporter{path: metricsFile}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want text, json, ndjson, or prometheus)", format)
+	}
+}
+
+// MultiReporter fans a round of results out to multiple reporters in
+// order, returning the first error encountered.
+type MultiReporter struct {
+	Reporters []Reporter
+}
+
+func (m MultiReporter) Report(results []checker.Result) error {
+	for _, r := range m.Reporters {
+		if err := r.Report(results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	colorReset = "\033[0m"
+	colorGreen = "\033[32m"
+	colorRed   = "\033[31m"
+	colorBold  = "\033[1m"
+)
+
+// textReporter prints a colorized table, one row per endpoint.
+type textReporter struct {
+	w io.Writer
+}
+
+func (t *textReporter) Report(results []checker.Result) error {
+	tw := tabwriter.NewWriter(t.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s%s\n", colorBold, "NAME", "TYPE", "STATUS", "LATENCY", "ERROR", colorReset)
+
+	for _, r := range results {
+		status, color := "DOWN", colorRed
+		if r.Healthy {
+			status, color = "UP", colorGreen
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s%s%s\t%s\t%s\n",
+			r.Name, r.Type, color, status, colorReset, r.Latency.Round(time.Millisecond), r.Error)
+	}
+
+	return tw.Flush()
+}
+
+// jsonReporter writes one JSON array of all results per tick.
+type jsonReporter struct {
+	w io.Writer
+}
+
+func (j *jsonReporter) Report(results []checker.Result) error {
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// ndjsonReporter writes one JSON object per result, newline-delimited, so
+// it can be piped into jq or a log shipper as the results arrive.
+type ndjsonReporter struct {
+	w io.Writer
+}
+
+func (n *ndjsonReporter) Report(results []checker.Result) error {
+	enc := json.NewEncoder(n.w)
+	for _, r := range results {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prometheusReporter writes node_exporter textfile-collector compatible
+// metrics to path on every tick, replacing the file atomically so a
+// concurrent scrape never reads a partial write.
+type prometheusReporter struct {
+	path string
+}
+
+func (p *prometheusReporter) Report(results []checker.Result) error {
+	var buf bytes.Buffer
+
+	buf.WriteString("# HELP healthcheck_up Whether the last check for this endpoint was healthy (1) or not (0).\n")
+	buf.WriteString("# TYPE healthcheck_up gauge\n")
+	for _, r := range results {
+		fmt.Fprintf(&buf, "healthcheck_up{endpoint=%q} %s\n", r.Name, boolMetric(r.Healthy))
+	}
+
+	buf.WriteString("# HELP healthcheck_latency_seconds Latency of the last check, in seconds.\n")
+	buf.WriteString("# TYPE healthcheck_latency_seconds gauge\n")
+	for _, r := range results {
+		fmt.Fprintf(&buf, "healthcheck_latency_seconds{endpoint=%q} %f\n", r.Name, r.Latency.Seconds())
+	}
+
+	// A gauge, not a counter: r.Retries is the retry count from only the
+	// most recent check round, which can rise or fall between ticks. A
+	// "_total"/counter metric here would break rate()/increase() queries,
+	// which assume a monotonically non-decreasing value.
+	buf.WriteString("# HELP healthcheck_retries_last Retries performed on the most recent check.\n")
+	buf.WriteString("# TYPE healthcheck_retries_last gauge\n")
+	for _, r := range results {
+		fmt.Fprintf(&buf, "healthcheck_retries_last{endpoint=%q} %d\n", r.Name, r.Retries)
+	}
+
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write metrics file: %w", err)
+	}
+	return os.Rename(tmp, p.path)
+}
+
+func boolMetric(healthy bool) string {
+	if healthy {
+		return "1"
+	}
+	return "0"
+}
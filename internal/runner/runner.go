@@ -0,0 +1,98 @@
+// Package runner drives repeated health check rounds and streams their
+// results to a pluggable Reporter.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go-health-checker/internal/alert"
+	"go-health-checker/internal/checker"
+	"go-health-checker/internal/config"
+)
+
+// Watch runs Engine.CheckAll on a timer, streaming each round's results to
+// reporter and, if dispatcher is non-nil, through the alert dispatcher. It
+// runs a single round when once is true, otherwise it loops every interval
+// until ctx is cancelled. The returned healthy reflects whether every
+// endpoint passed in the final round.
+func Watch(ctx context.Context, engine *checker.Engine, endpoints []config.Endpoint, interval time.Duration, reporter Reporter, dispatcher *alert.Dispatcher, once bool) (healthy bool, err error) {
+	for {
+		results := engine.CheckAll(ctx, endpoints)
+		healthy = allHealthy(results)
+
+		if err := reporter.Report(results); err != nil {
+			return healthy, fmt.Errorf("report results: %w", err)
+		}
+
+		if dispatcher != nil {
+			dispatcher.Dispatch(ctx, results)
+		}
+
+		if once {
+			return healthy, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return healthy, nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// RetryUntilPass repeatedly runs a full check round over endpoints until
+// every endpoint passes or retryTimeout elapses. It is meant to gate
+// CI/CD deploys: wait for a freshly deployed service to become healthy
+// before letting the pipeline continue. Each endpoint's own Retries
+// behavior (checker.Options.Retries) still applies inside every attempt;
+// this adds an outer retry loop across the whole batch.
+func RetryUntilPass(ctx context.Context, engine *checker.Engine, endpoints []config.Endpoint, retryTimeout, sleep time.Duration, out io.Writer) (passed bool, err error) {
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		results := engine.CheckAll(ctx, endpoints)
+		if allHealthy(results) {
+			return true, nil
+		}
+
+		fmt.Fprintf(out, "%s%sAttempt #%d%s: %d/%d endpoints healthy\n",
+			colorBold, colorRed, attempt, colorReset, countHealthy(results), len(results))
+		for _, r := range results {
+			if !r.Healthy {
+				fmt.Fprintf(out, "  %s%s%s: %s\n", colorRed, r.Name, colorReset, r.Error)
+			}
+		}
+
+		if time.Since(start)+sleep > retryTimeout {
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(sleep):
+		}
+	}
+}
+
+func countHealthy(results []checker.Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Healthy {
+			n++
+		}
+	}
+	return n
+}
+
+func allHealthy(results []checker.Result) bool {
+	for _, r := range results {
+		if !r.Healthy {
+			return false
+		}
+	}
+	return true
+}